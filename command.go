@@ -0,0 +1,86 @@
+package shireikan
+
+import "regexp"
+
+// Command specifies a command which can be registered
+// to and executed by a Handler.
+type Command interface {
+
+	// GetInvokes returns the aliases this command is
+	// executed by, whereas the first entry is treated
+	// as the main invoke.
+	GetInvokes() []string
+
+	// GetDescription returns a short, human readable
+	// description of the commands function which is
+	// displayed by the default help command.
+	GetDescription() string
+
+	// IsExecutableInDMChannels specifies whether this
+	// command can be executed in DM and Group DM channels.
+	IsExecutableInDMChannels() bool
+
+	// Exec is executed when the command was invoked by
+	// a chat message.
+	Exec(ctx Context) error
+}
+
+// Filter guards whether a Command is allowed to execute for
+// a given invocation. It runs after invoke (and sub command)
+// resolution, but before the command's Exec is called.
+type Filter interface {
+	// Check returns whether ctx is allowed to proceed to the
+	// command's Exec handler. When pass is false, reason is
+	// passed to OnError as the error value and the command is
+	// not executed.
+	Check(ctx Context) (pass bool, reason error)
+}
+
+// Filterable is an optional interface a Command may implement
+// to attach a chain of Filter instances which are checked,
+// in order, before the command is executed.
+type Filterable interface {
+	// GetFilters returns the filters guarding this command.
+	GetFilters() []Filter
+}
+
+// Documented is an optional interface a Command may implement
+// to have its argument count validated automatically and to
+// have usage information rendered by the default help command.
+type Documented interface {
+	// GetMinArgs returns the minimum number of arguments this
+	// command requires. Invocations with fewer are rejected
+	// with ErrTypInsufficientArgs before Exec is called.
+	GetMinArgs() int
+
+	// GetUsage returns a short usage hint for the arguments
+	// this command expects, not including the prefix or
+	// invoke, e.g. "<user> <reason>".
+	GetUsage() string
+}
+
+// RegexCommand is an optional interface a Command may
+// implement to be matched by a regular expression against the
+// content following the prefix, instead of a literal invoke.
+// A command's literal invokes, if matched, always take
+// precedence over any RegexCommand pattern.
+type RegexCommand interface {
+	// GetPatterns returns the compiled patterns this command
+	// is dispatched on. Patterns are tried in order and the
+	// first one to match wins. Patterns are compiled with the
+	// standard library's regexp package (RE2), which guards
+	// against catastrophic backtracking.
+	GetPatterns() []*regexp.Regexp
+}
+
+// SubCommander is an optional interface a Command may
+// implement to expose child commands. After a command
+// implementing SubCommander has been resolved, the handler
+// keeps consuming arguments as long as they match one of the
+// returned child invokes, descending until a leaf command is
+// reached.
+type SubCommander interface {
+	// GetSubCommands returns the child commands registered
+	// under this command.
+	GetSubCommands() []Command
+}
@@ -0,0 +1,147 @@
+package shireikan
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Context wraps the state of an invoked command and gives
+// access to the underlying discordgo objects as well as a
+// per-invocation object map.
+type Context interface {
+
+	// GetSession returns the discordgo session the
+	// command was invoked on.
+	GetSession() *discordgo.Session
+
+	// GetMessage returns the message which invoked
+	// the command.
+	GetMessage() *discordgo.Message
+
+	// GetMember returns the guild member who sent the
+	// message. This is nil when the command was invoked
+	// in a DM channel.
+	GetMember() *discordgo.Member
+
+	// GetChannel returns the channel the message was
+	// sent in.
+	GetChannel() *discordgo.Channel
+
+	// GetGuild returns the guild the message was sent in.
+	// This is nil when the command was invoked in a DM
+	// channel.
+	GetGuild() *discordgo.Guild
+
+	// GetArgs returns the arguments passed after the
+	// invoke.
+	GetArgs() ArgumentList
+
+	// IsDM returns whether the command was invoked in a
+	// DM or Group DM channel.
+	IsDM() bool
+
+	// IsEdit returns whether the command was invoked by
+	// editing a previously sent message.
+	IsEdit() bool
+
+	// InvokePath returns the full chain of invokes used to
+	// reach the executed command, starting with the top-level
+	// invoke and followed by the invoke of each descended
+	// SubCommander child.
+	InvokePath() []string
+
+	// Named returns the value captured by the named group
+	// "name" when the executed command was resolved via a
+	// RegexCommand pattern. It returns an empty string when
+	// the command was resolved by literal invoke, or when no
+	// group by that name matched.
+	Named(name string) string
+
+	// GetObject returns a value from the per-invocation
+	// object map by the given key.
+	GetObject(key string) interface{}
+
+	// SetObject sets a value to the per-invocation object
+	// map by the given key.
+	SetObject(key string, val interface{})
+
+	// Ctx returns the context.Context this invocation runs
+	// under. It is cancelled once Config.ExecTimeout elapses
+	// (if set) or once the middleware and Exec pipeline
+	// returns, whichever happens first. Commands performing
+	// long-running work should select on Ctx().Done() to abort
+	// cleanly.
+	Ctx() context.Context
+}
+
+// cmdContext is the default implementation of Context.
+type cmdContext struct {
+	session    *discordgo.Session
+	message    *discordgo.Message
+	member     *discordgo.Member
+	channel    *discordgo.Channel
+	guild      *discordgo.Guild
+	args       ArgumentList
+	isDM       bool
+	isEdit     bool
+	invokePath []string
+	named      map[string]string
+	ctx        context.Context
+
+	objectMap *sync.Map
+}
+
+func (c *cmdContext) GetSession() *discordgo.Session {
+	return c.session
+}
+
+func (c *cmdContext) GetMessage() *discordgo.Message {
+	return c.message
+}
+
+func (c *cmdContext) GetMember() *discordgo.Member {
+	return c.member
+}
+
+func (c *cmdContext) GetChannel() *discordgo.Channel {
+	return c.channel
+}
+
+func (c *cmdContext) GetGuild() *discordgo.Guild {
+	return c.guild
+}
+
+func (c *cmdContext) GetArgs() ArgumentList {
+	return c.args
+}
+
+func (c *cmdContext) IsDM() bool {
+	return c.isDM
+}
+
+func (c *cmdContext) IsEdit() bool {
+	return c.isEdit
+}
+
+func (c *cmdContext) InvokePath() []string {
+	return c.invokePath
+}
+
+func (c *cmdContext) Named(name string) string {
+	return c.named[name]
+}
+
+func (c *cmdContext) Ctx() context.Context {
+	return c.ctx
+}
+
+func (c *cmdContext) GetObject(key string) interface{} {
+	val, _ := c.objectMap.Load(key)
+	return val
+}
+
+func (c *cmdContext) SetObject(key string, val interface{}) {
+	c.objectMap.Store(key, val)
+}
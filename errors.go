@@ -0,0 +1,14 @@
+package shireikan
+
+import "errors"
+
+var (
+	// ErrCommandNotFound is passed to OnError when no command
+	// was found by the given invoke.
+	ErrCommandNotFound = errors.New("command not found")
+
+	// ErrCommandNotExecutableInDMs is passed to OnError when a
+	// command which is not executable in DM channels was
+	// invoked in a DM or Group DM channel.
+	ErrCommandNotExecutableInDMs = errors.New("command not executable in DM channels")
+)
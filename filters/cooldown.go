@@ -0,0 +1,43 @@
+package filters
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jameshclrk/shireikan"
+)
+
+// Cooldown returns a Filter which rejects invocations from a
+// user within d of the last invocation this filter let through
+// for them, regardless of whether that invocation's command then
+// ran or succeeded.
+func Cooldown(d time.Duration) shireikan.Filter {
+	return &cooldownFilter{
+		duration: d,
+		lastUse:  make(map[string]time.Time),
+	}
+}
+
+type cooldownFilter struct {
+	duration time.Duration
+
+	mu      sync.Mutex
+	lastUse map[string]time.Time
+}
+
+func (f *cooldownFilter) Check(ctx shireikan.Context) (bool, error) {
+	userID := ctx.GetMessage().Author.ID
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if last, ok := f.lastUse[userID]; ok {
+		if since := time.Since(last); since < f.duration {
+			return false, fmt.Errorf("%w: try again in %s", ErrOnCooldown, (f.duration - since).Round(time.Second))
+		}
+	}
+
+	f.lastUse[userID] = time.Now()
+	return true, nil
+}
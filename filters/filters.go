@@ -0,0 +1,24 @@
+// Package filters provides a set of common, ready to use
+// shireikan.Filter implementations to attach to individual
+// commands via shireikan.Filterable.
+package filters
+
+import "errors"
+
+var (
+	// ErrNotInGuild is returned by RequireGuild when the
+	// command was invoked outside of a guild channel.
+	ErrNotInGuild = errors.New("this command can only be used in a server")
+
+	// ErrUserNotAllowed is returned by RequireUserID when the
+	// invoking user is not part of the allow list.
+	ErrUserNotAllowed = errors.New("you are not allowed to use this command")
+
+	// ErrMissingPermission is returned by RequirePermission
+	// when the invoking member lacks the required permission.
+	ErrMissingPermission = errors.New("you do not have permission to use this command")
+
+	// ErrOnCooldown is returned by Cooldown when the invoking
+	// user is still within the cooldown period.
+	ErrOnCooldown = errors.New("this command is on cooldown, please wait before using it again")
+)
@@ -0,0 +1,119 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/jameshclrk/shireikan"
+)
+
+// fakeContext is a minimal shireikan.Context stub used to drive
+// filters directly, without going through a full Handler dispatch.
+type fakeContext struct {
+	session *discordgo.Session
+	message *discordgo.Message
+	channel *discordgo.Channel
+	isDM    bool
+}
+
+func (c fakeContext) GetSession() *discordgo.Session  { return c.session }
+func (c fakeContext) GetMessage() *discordgo.Message  { return c.message }
+func (c fakeContext) GetMember() *discordgo.Member    { return nil }
+func (c fakeContext) GetChannel() *discordgo.Channel  { return c.channel }
+func (c fakeContext) GetGuild() *discordgo.Guild      { return nil }
+func (c fakeContext) GetArgs() shireikan.ArgumentList { return nil }
+func (c fakeContext) IsDM() bool                      { return c.isDM }
+func (c fakeContext) IsEdit() bool                    { return false }
+func (c fakeContext) InvokePath() []string            { return nil }
+func (c fakeContext) Named(string) string             { return "" }
+func (c fakeContext) GetObject(string) interface{}    { return nil }
+func (c fakeContext) SetObject(string, interface{})   {}
+func (c fakeContext) Ctx() context.Context            { return context.Background() }
+
+func TestRequireGuildFilter(t *testing.T) {
+	filter := RequireGuild()
+
+	if pass, err := filter.Check(fakeContext{isDM: false}); !pass || err != nil {
+		t.Fatalf("got pass=%v err=%v, want pass=true err=nil for a guild invocation", pass, err)
+	}
+
+	pass, err := filter.Check(fakeContext{isDM: true})
+	if pass {
+		t.Fatal("expected filter to reject a DM invocation")
+	}
+	if !errors.Is(err, ErrNotInGuild) {
+		t.Fatalf("got err %v, want ErrNotInGuild", err)
+	}
+}
+
+func TestRequirePermissionFilter(t *testing.T) {
+	s := &discordgo.Session{State: discordgo.NewState()}
+
+	guild := &discordgo.Guild{
+		ID:      "guild1",
+		OwnerID: "owner1",
+		Roles: []*discordgo.Role{
+			{ID: "guild1", Permissions: discordgo.PermissionViewChannel},
+		},
+	}
+	channel := &discordgo.Channel{ID: "chan1", GuildID: guild.ID, Type: discordgo.ChannelTypeGuildText}
+	member := &discordgo.Member{GuildID: guild.ID, User: &discordgo.User{ID: "user1"}}
+
+	for _, err := range []error{
+		s.State.GuildAdd(guild),
+		s.State.ChannelAdd(channel),
+		s.State.MemberAdd(member),
+	} {
+		if err != nil {
+			t.Fatalf("failed to seed state: %v", err)
+		}
+	}
+
+	ctx := fakeContext{
+		session: s,
+		message: &discordgo.Message{Author: &discordgo.User{ID: "user1"}},
+		channel: channel,
+	}
+
+	if pass, err := RequirePermission(discordgo.PermissionViewChannel).Check(ctx); !pass || err != nil {
+		t.Fatalf("got pass=%v err=%v, want pass=true err=nil for a held permission", pass, err)
+	}
+
+	pass, err := RequirePermission(discordgo.PermissionAdministrator).Check(ctx)
+	if pass {
+		t.Fatal("expected filter to reject a missing permission")
+	}
+	if !errors.Is(err, ErrMissingPermission) {
+		t.Fatalf("got err %v, want ErrMissingPermission", err)
+	}
+
+	dmCtx := fakeContext{session: s, message: ctx.message, isDM: true}
+	if pass, err := RequirePermission(discordgo.PermissionAdministrator).Check(dmCtx); !pass || err != nil {
+		t.Fatalf("got pass=%v err=%v, want pass=true err=nil in a DM channel", pass, err)
+	}
+}
+
+func TestCooldownFilter(t *testing.T) {
+	filter := Cooldown(30 * time.Millisecond)
+	ctx := fakeContext{message: &discordgo.Message{Author: &discordgo.User{ID: "user1"}}}
+
+	if pass, err := filter.Check(ctx); !pass || err != nil {
+		t.Fatalf("got pass=%v err=%v, want pass=true err=nil for the first invocation", pass, err)
+	}
+
+	pass, err := filter.Check(ctx)
+	if pass {
+		t.Fatal("expected the immediately following invocation to be rejected")
+	}
+	if !errors.Is(err, ErrOnCooldown) {
+		t.Fatalf("got err %v, want ErrOnCooldown", err)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if pass, err := filter.Check(ctx); !pass || err != nil {
+		t.Fatalf("got pass=%v err=%v, want pass=true err=nil once the cooldown has elapsed", pass, err)
+	}
+}
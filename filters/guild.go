@@ -0,0 +1,18 @@
+package filters
+
+import "github.com/jameshclrk/shireikan"
+
+// RequireGuild returns a Filter which rejects invocations
+// which did not happen in a guild channel.
+func RequireGuild() shireikan.Filter {
+	return requireGuildFilter{}
+}
+
+type requireGuildFilter struct{}
+
+func (requireGuildFilter) Check(ctx shireikan.Context) (bool, error) {
+	if ctx.IsDM() {
+		return false, ErrNotInGuild
+	}
+	return true, nil
+}
@@ -0,0 +1,34 @@
+package filters
+
+import "github.com/jameshclrk/shireikan"
+
+// RequirePermission returns a Filter which rejects invocations
+// from members that don't have every permission bit set in
+// perm for the channel the command was invoked in. It always
+// passes in DM channels, since channel permissions don't apply
+// there.
+func RequirePermission(perm int64) shireikan.Filter {
+	return requirePermissionFilter{perm: perm}
+}
+
+type requirePermissionFilter struct {
+	perm int64
+}
+
+func (f requirePermissionFilter) Check(ctx shireikan.Context) (bool, error) {
+	if ctx.IsDM() {
+		return true, nil
+	}
+
+	userPerms, err := ctx.GetSession().State.UserChannelPermissions(
+		ctx.GetMessage().Author.ID, ctx.GetChannel().ID)
+	if err != nil {
+		return false, err
+	}
+
+	if userPerms&f.perm != f.perm {
+		return false, ErrMissingPermission
+	}
+
+	return true, nil
+}
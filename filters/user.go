@@ -0,0 +1,23 @@
+package filters
+
+import "github.com/jameshclrk/shireikan"
+
+// RequireUserID returns a Filter which rejects invocations
+// from any user whose ID is not contained in ids.
+func RequireUserID(ids ...string) shireikan.Filter {
+	return requireUserIDFilter{ids: ids}
+}
+
+type requireUserIDFilter struct {
+	ids []string
+}
+
+func (f requireUserIDFilter) Check(ctx shireikan.Context) (bool, error) {
+	userID := ctx.GetMessage().Author.ID
+	for _, id := range f.ids {
+		if id == userID {
+			return true, nil
+		}
+	}
+	return false, ErrUserNotAllowed
+}
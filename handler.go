@@ -3,10 +3,13 @@
 package shireikan
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"runtime/debug"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -24,16 +27,41 @@ const (
 	ErrTypMiddleware                            // Middleware handler returned an error
 	ErrTypCommandExec                           // Command handler returned an error
 	ErrTypDeleteCommandMessage                  // Deleting command message failed
+	ErrTypArgsParse                             // ArgsParser failed to tokenize the message content
+	ErrTypFilterRejected                        // A commands filter rejected the invocation
+	ErrTypInsufficientArgs                      // Too few arguments were passed for the resolved command
+	ErrTypCommandPanic                          // Command handler panicked during execution
 )
 
 var (
 	argsRx = regexp.MustCompile(`(?:[^\s"]+|"[^"]*")+`)
 )
 
+// ArgsParser tokenizes message content into a slice of
+// arguments. It is called with the used prefix, and the single
+// space after it when SpaceAfterPrefix is set, already removed,
+// so the returned slice is what invoke and sub command splitting
+// operate on.
+type ArgsParser func(content string) ([]string, error)
+
+// DefaultArgsParser is the ArgsParser used when Config.ArgsParser
+// is not set. It mirrors the historic behavior of splitting on
+// whitespace while treating double-quoted substrings as a single
+// token, stripping the quotes themselves.
+func DefaultArgsParser(content string) ([]string, error) {
+	args := argsRx.FindAllString(content, -1)
+	for i, k := range args {
+		if strings.Contains(k, "\"") {
+			args[i] = strings.Replace(k, "\"", "", -1)
+		}
+	}
+	return args, nil
+}
+
 // Config wraps configuration values for the CommandHandler.
 type Config struct {
 	GeneralPrefix         string `json:"general_prefix"`           // General and globally accessible prefix
-	SpaceAfterPrefix      bool   `json:"space_after_prefix"`       // Make commands run with a space after the prefix
+	SpaceAfterPrefix      bool   `json:"space_after_prefix"`       // Make commands run with a space after the prefix (ignored when Prefixer is set; fold it into the Prefixer instead)
 	InvokeToLower         bool   `json:"invoke_to_lower"`          // Lowercase command invoke befor map matching
 	AllowDM               bool   `json:"allow_dm"`                 // Allow commands to be executed in DM and GroupDM channels
 	AllowBots             bool   `json:"allow_bots"`               // Allow bot accounts to execute commands
@@ -58,7 +86,36 @@ type Config struct {
 	// string is empty when no guild prefix is specified.
 	// An error is only returned when the retrieving of the
 	// guild prefix failed unexpectedly.
+	//
+	// GeneralPrefix and GuildPrefixGetter are only consulted
+	// when Prefixer is nil, for backward compatibility.
 	GuildPrefixGetter func(guildID string) (string, error)
+
+	// Prefixer determines the prefix used to invoke commands.
+	// When set, it takes precedence over GeneralPrefix and
+	// GuildPrefixGetter. See StaticPrefix, GuildPrefix,
+	// MentionPrefix and AnyPrefix for ready to use
+	// implementations.
+	Prefixer Prefixer
+
+	// ArgsParser tokenizes the message content following the
+	// prefix into the slice of arguments used for invoke, sub
+	// command and command argument extraction. Defaults to
+	// DefaultArgsParser, which mirrors the handlers historic
+	// quoting behavior.
+	ArgsParser ArgsParser
+
+	// ExecTimeout sets a deadline on the Context.Ctx() of a
+	// single invocation's middleware and command Exec pipeline.
+	// The pipeline is not interrupted when it passes; a command
+	// only stops early if it observes Ctx().Done(). Zero (the
+	// default) means no timeout is applied.
+	ExecTimeout time.Duration
+
+	// RootContext is the parent context each invocation's
+	// Context.Ctx() is derived from. Defaults to
+	// context.Background() when nil.
+	RootContext context.Context
 }
 
 // Handler specifies a command register and handler.
@@ -112,6 +169,7 @@ type handler struct {
 	config       *Config
 	cmdMap       map[string]Command
 	cmdInstances []Command
+	patternCmds  []Command
 	middlewares  []Middleware
 	objectMap    *sync.Map
 }
@@ -129,6 +187,10 @@ func NewHandler(cfg *Config) Handler {
 		}
 	}
 
+	if cfg.ArgsParser == nil {
+		cfg.ArgsParser = DefaultArgsParser
+	}
+
 	handler := &handler{
 		config:       cfg,
 		cmdMap:       make(map[string]Command),
@@ -154,6 +216,87 @@ func (h *handler) RegisterCommand(cmd Command) {
 		}
 		h.cmdMap[invoke] = cmd
 	}
+
+	if sc, ok := cmd.(SubCommander); ok {
+		validateSubCommands(sc.GetSubCommands(), h.config.InvokeToLower)
+	}
+
+	if _, ok := cmd.(RegexCommand); ok {
+		h.patternCmds = append(h.patternCmds, cmd)
+	}
+}
+
+// matchPattern scans the registered RegexCommand patterns, in
+// registration order, for the first one matching content. The
+// returned map holds the named capture groups of the pattern
+// that matched.
+func (h *handler) matchPattern(content string) (Command, map[string]string, bool) {
+	for _, cmd := range h.patternCmds {
+		rc := cmd.(RegexCommand)
+		for _, re := range rc.GetPatterns() {
+			groups := re.FindStringSubmatch(content)
+			if groups == nil {
+				continue
+			}
+
+			named := make(map[string]string, len(groups))
+			for i, name := range re.SubexpNames() {
+				if i == 0 || name == "" {
+					continue
+				}
+				named[name] = groups[i]
+			}
+
+			return cmd, named, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// validateSubCommands panics when two sibling commands in cmds
+// share an invoke, mirroring the panic RegisterCommand raises
+// for top-level invoke collisions. It recurses into any child
+// which is itself a SubCommander.
+func validateSubCommands(cmds []Command, invokeToLower bool) {
+	seen := make(map[string]bool)
+	for _, cmd := range cmds {
+		for _, invoke := range cmd.GetInvokes() {
+			if invokeToLower {
+				invoke = strings.ToLower(invoke)
+			}
+			if seen[invoke] {
+				panic(fmt.Sprintf("invoke '%s' already registered as sub command", invoke))
+			}
+			seen[invoke] = true
+		}
+
+		if sc, ok := cmd.(SubCommander); ok {
+			validateSubCommands(sc.GetSubCommands(), invokeToLower)
+		}
+	}
+}
+
+// findSubCommand returns the child of cmds whose invoke matches
+// invoke, honoring invokeToLower the same way the top-level
+// command map does.
+func findSubCommand(cmds []Command, invoke string, invokeToLower bool) (Command, bool) {
+	if invokeToLower {
+		invoke = strings.ToLower(invoke)
+	}
+
+	for _, cmd := range cmds {
+		for _, in := range cmd.GetInvokes() {
+			if invokeToLower {
+				in = strings.ToLower(in)
+			}
+			if in == invoke {
+				return cmd, true
+			}
+		}
+	}
+
+	return nil, false
 }
 
 func (h *handler) RegisterMiddleware(mw Middleware) {
@@ -217,31 +360,44 @@ func (h *handler) messageHandler(s *discordgo.Session, msg *discordgo.Message, i
 		return
 	}
 
-	ctx := &context{
+	ctx := &cmdContext{
 		session: s,
 		message: msg,
 		member:  msg.Member,
 		isEdit:  isEdit,
+		ctx:     context.Background(),
 	}
 
 	var err error
 
 	usedPrefix := ""
-	if strings.HasPrefix(msg.Content, h.config.GeneralPrefix) {
-		usedPrefix = h.config.GeneralPrefix
-	} else {
-		guildPrefix, err := h.config.GuildPrefixGetter(msg.GuildID)
+	if h.config.Prefixer != nil {
+		prefix, ok, err := h.config.Prefixer(s, msg)
 		if err != nil {
 			h.config.OnError(ctx, ErrTypGuildPrefixGetter, err)
 			return
 		}
-		if guildPrefix != "" && strings.HasPrefix(msg.Content, guildPrefix) {
-			usedPrefix = guildPrefix
+		if !ok {
+			return
+		}
+		usedPrefix = prefix
+	} else {
+		if strings.HasPrefix(msg.Content, h.config.GeneralPrefix) {
+			usedPrefix = h.config.GeneralPrefix
+		} else {
+			guildPrefix, err := h.config.GuildPrefixGetter(msg.GuildID)
+			if err != nil {
+				h.config.OnError(ctx, ErrTypGuildPrefixGetter, err)
+				return
+			}
+			if guildPrefix != "" && strings.HasPrefix(msg.Content, guildPrefix) {
+				usedPrefix = guildPrefix
+			}
 		}
-	}
 
-	if usedPrefix == "" {
-		return
+		if usedPrefix == "" {
+			return
+		}
 	}
 
 	if ctx.channel, err = s.State.Channel(msg.ChannelID); err != nil {
@@ -265,35 +421,68 @@ func (h *handler) messageHandler(s *discordgo.Session, msg *discordgo.Message, i
 		}
 	}
 
-	args := argsRx.FindAllString(msg.Content, -1)
-	for i, k := range args {
-		if strings.Contains(k, "\"") {
-			args[i] = strings.Replace(k, "\"", "", -1)
+	rest := msg.Content[len(usedPrefix):]
+	if h.config.Prefixer == nil && h.config.SpaceAfterPrefix {
+		if !strings.HasPrefix(rest, " ") {
+			return
 		}
+		rest = rest[1:]
+	}
+
+	args, err := h.config.ArgsParser(rest)
+	if err != nil {
+		h.config.OnError(ctx, ErrTypArgsParse, err)
+		return
 	}
 
 	var invoke string
-	if h.config.SpaceAfterPrefix {
-		if len(args) > 1 {
-			invoke = args[1]
-			args = args[2:]
-		} else {
-			invoke = ""
-			args = args[1:]
-		}
-	} else {
-		invoke = args[0][len(usedPrefix):]
+	if len(args) > 0 {
+		invoke = args[0]
 		args = args[1:]
 	}
 
-	ctx.args = ArgumentList(args)
-
 	cmd, ok := h.GetCommand(invoke)
+	invokePath := []string{invoke}
+	var named map[string]string
+
 	if !ok {
-		h.config.OnError(ctx, ErrTypCommandNotFound, ErrCommandNotFound)
-		return
+		cmd, named, ok = h.matchPattern(strings.TrimSpace(rest))
+		if !ok {
+			h.config.OnError(ctx, ErrTypCommandNotFound, ErrCommandNotFound)
+			return
+		}
+
+		// A RegexCommand has no literal invoke to strip, so the
+		// leading token popped above isn't an invoke at all and
+		// must not be dropped from the arguments. Re-tokenize the
+		// full, matched content instead of reusing args.
+		if args, err = h.config.ArgsParser(rest); err != nil {
+			h.config.OnError(ctx, ErrTypArgsParse, err)
+			return
+		}
+		invokePath = nil
+	} else {
+		for {
+			sc, isSub := cmd.(SubCommander)
+			if !isSub || len(args) == 0 {
+				break
+			}
+
+			child, found := findSubCommand(sc.GetSubCommands(), args[0], h.config.InvokeToLower)
+			if !found {
+				break
+			}
+
+			cmd = child
+			invokePath = append(invokePath, args[0])
+			args = args[1:]
+		}
 	}
 
+	ctx.args = ArgumentList(args)
+	ctx.invokePath = invokePath
+	ctx.named = named
+
 	if ctx.isDM && !cmd.IsExecutableInDMChannels() {
 		h.config.OnError(ctx, ErrTypNotExecutableInDM, ErrCommandNotExecutableInDMs)
 		return
@@ -302,11 +491,49 @@ func (h *handler) messageHandler(s *discordgo.Session, msg *discordgo.Message, i
 	ctx.objectMap = &sync.Map{}
 	ctx.SetObject(ObjectMapKeyHandler, h)
 
+	rootCtx := h.config.RootContext
+	if rootCtx == nil {
+		rootCtx = context.Background()
+	}
+
+	execCtx := rootCtx
+	var cancel context.CancelFunc
+	if h.config.ExecTimeout > 0 {
+		execCtx, cancel = context.WithTimeout(rootCtx, h.config.ExecTimeout)
+	} else {
+		execCtx, cancel = context.WithCancel(rootCtx)
+	}
+	defer cancel()
+	ctx.ctx = execCtx
+
 	if !h.executeMiddlewares(cmd, ctx, LayerBeforeCommand) {
 		return
 	}
 
-	if err = cmd.Exec(ctx); err != nil {
+	if doc, ok := cmd.(Documented); ok {
+		if minArgs := doc.GetMinArgs(); len(ctx.args) < minArgs {
+			usage := fmt.Sprintf("%s%s %s", usedPrefix, strings.Join(invokePath, " "), doc.GetUsage())
+			h.config.OnError(ctx, ErrTypInsufficientArgs, fmt.Errorf("usage: %s", usage))
+			return
+		}
+	}
+
+	if f, ok := cmd.(Filterable); ok {
+		for _, filter := range f.GetFilters() {
+			pass, reason := filter.Check(ctx)
+			if !pass {
+				h.config.OnError(ctx, ErrTypFilterRejected, reason)
+				return
+			}
+		}
+	}
+
+	err, panicked := h.execCommand(cmd, ctx)
+	if panicked {
+		h.config.OnError(ctx, ErrTypCommandPanic, err)
+		return
+	}
+	if err != nil {
 		h.config.OnError(ctx, ErrTypCommandExec, err)
 		return
 	}
@@ -323,6 +550,22 @@ func (h *handler) messageHandler(s *discordgo.Session, msg *discordgo.Message, i
 	}
 }
 
+// execCommand calls cmd.Exec, recovering from any panic so a
+// crashing command never tears down the surrounding discordgo
+// handler goroutine. panicked reports whether a panic occurred,
+// in which case err carries the recovered value and stack trace.
+func (h *handler) execCommand(cmd Command, ctx Context) (err error, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			err = fmt.Errorf("command panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	err = cmd.Exec(ctx)
+	return
+}
+
 func (h *handler) executeMiddlewares(cmd Command, ctx Context, layer MiddlewareLayer) bool {
 	for _, mw := range h.middlewares {
 		if mw.GetLayer()&layer == 0 {
@@ -0,0 +1,80 @@
+package shireikan
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMessageHandlerRecoversCommandPanic(t *testing.T) {
+	cmd := &stubCommand{
+		invokes: []string{"boom"},
+		execFn: func(ctx Context) error {
+			panic("kaboom")
+		},
+	}
+
+	var gotType ErrorType
+	var gotErr error
+	h := NewHandler(&Config{
+		GeneralPrefix: "!",
+		AllowDM:       true,
+		OnError: func(_ Context, errTyp ErrorType, err error) {
+			gotType = errTyp
+			gotErr = err
+		},
+	}).(*handler)
+	h.RegisterCommand(cmd)
+
+	s := newTestSession("bot1")
+	msg := newTestDMMessage(t, s, "!boom")
+
+	// Should not panic out of the call despite cmd.Exec panicking.
+	h.messageHandler(s, msg, false)
+
+	if gotType != ErrTypCommandPanic {
+		t.Fatalf("got error type %v, want ErrTypCommandPanic", gotType)
+	}
+	if gotErr == nil {
+		t.Fatal("expected a non-nil recovered error")
+	}
+}
+
+func TestMessageHandlerExecTimeoutCancelsCtx(t *testing.T) {
+	var observedErr error
+
+	cmd := &stubCommand{
+		invokes: []string{"wait"},
+		execFn: func(ctx Context) error {
+			<-ctx.Ctx().Done()
+			observedErr = ctx.Ctx().Err()
+			return nil
+		},
+	}
+
+	h := NewHandler(&Config{
+		GeneralPrefix: "!",
+		AllowDM:       true,
+		ExecTimeout:   20 * time.Millisecond,
+	}).(*handler)
+	h.RegisterCommand(cmd)
+
+	s := newTestSession("bot1")
+	msg := newTestDMMessage(t, s, "!wait")
+
+	done := make(chan struct{})
+	go func() {
+		h.messageHandler(s, msg, false)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("messageHandler did not return, ExecTimeout does not appear to cancel Ctx()")
+	}
+
+	if observedErr != context.DeadlineExceeded {
+		t.Fatalf("got ctx error %v, want context.DeadlineExceeded", observedErr)
+	}
+}
@@ -0,0 +1,93 @@
+package shireikan
+
+import (
+	"strings"
+	"testing"
+)
+
+// docStubCommand is a minimal Command additionally implementing
+// Documented, to drive MinArgs validation and usage synthesis.
+type docStubCommand struct {
+	invokes []string
+	minArgs int
+	usage   string
+	execFn  func(ctx Context) error
+}
+
+func (c *docStubCommand) GetInvokes() []string           { return c.invokes }
+func (c *docStubCommand) GetDescription() string         { return "doc stub" }
+func (c *docStubCommand) IsExecutableInDMChannels() bool { return true }
+func (c *docStubCommand) GetMinArgs() int                { return c.minArgs }
+func (c *docStubCommand) GetUsage() string               { return c.usage }
+
+func (c *docStubCommand) Exec(ctx Context) error {
+	if c.execFn == nil {
+		return nil
+	}
+	return c.execFn(ctx)
+}
+
+func TestMessageHandlerRejectsInsufficientArgs(t *testing.T) {
+	var execCalled bool
+	cmd := &docStubCommand{
+		invokes: []string{"set"},
+		minArgs: 2,
+		usage:   "<key> <value>",
+		execFn: func(ctx Context) error {
+			execCalled = true
+			return nil
+		},
+	}
+
+	var gotType ErrorType
+	var gotErr error
+	h := NewHandler(&Config{
+		GeneralPrefix: "!",
+		AllowDM:       true,
+		OnError: func(_ Context, errTyp ErrorType, err error) {
+			gotType = errTyp
+			gotErr = err
+		},
+	}).(*handler)
+	h.RegisterCommand(cmd)
+
+	s := newTestSession("bot1")
+	msg := newTestDMMessage(t, s, "!set onlyone")
+
+	h.messageHandler(s, msg, false)
+
+	if execCalled {
+		t.Fatal("expected Exec not to run for an invocation below MinArgs")
+	}
+	if gotType != ErrTypInsufficientArgs {
+		t.Fatalf("got error type %v, want ErrTypInsufficientArgs", gotType)
+	}
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "!set <key> <value>") {
+		t.Fatalf("got err %v, want it to contain the synthesized usage \"!set <key> <value>\"", gotErr)
+	}
+}
+
+func TestMessageHandlerRunsOnceMinArgsIsMet(t *testing.T) {
+	var execCalled bool
+	cmd := &docStubCommand{
+		invokes: []string{"set"},
+		minArgs: 2,
+		usage:   "<key> <value>",
+		execFn: func(ctx Context) error {
+			execCalled = true
+			return nil
+		},
+	}
+
+	h := NewHandler(&Config{GeneralPrefix: "!", AllowDM: true}).(*handler)
+	h.RegisterCommand(cmd)
+
+	s := newTestSession("bot1")
+	msg := newTestDMMessage(t, s, "!set key value")
+
+	h.messageHandler(s, msg, false)
+
+	if !execCalled {
+		t.Fatal("expected Exec to run once MinArgs is satisfied")
+	}
+}
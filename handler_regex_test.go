@@ -0,0 +1,92 @@
+package shireikan
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// regexStubCommand is a stubCommand additionally dispatched by
+// pattern instead of literal invoke.
+type regexStubCommand struct {
+	stubCommand
+	patterns []*regexp.Regexp
+}
+
+func (c *regexStubCommand) GetPatterns() []*regexp.Regexp { return c.patterns }
+
+func TestMessageHandlerRegexDispatch(t *testing.T) {
+	var gotArgs ArgumentList
+	var gotWhen, gotWhat string
+
+	cmd := &regexStubCommand{
+		stubCommand: stubCommand{
+			execFn: func(ctx Context) error {
+				gotArgs = ctx.GetArgs()
+				gotWhen = ctx.Named("when")
+				gotWhat = ctx.Named("what")
+				return nil
+			},
+		},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^remind (?P<when>\S+) (?P<what>.+)$`),
+		},
+	}
+
+	h := NewHandler(&Config{GeneralPrefix: "!", AllowDM: true}).(*handler)
+	h.RegisterCommand(cmd)
+
+	s := newTestSession("bot1")
+	msg := newTestDMMessage(t, s, "!remind 5m take out the trash")
+
+	h.messageHandler(s, msg, false)
+
+	if gotWhen != "5m" {
+		t.Fatalf("got Named(\"when\")=%q, want \"5m\"", gotWhen)
+	}
+	if gotWhat != "take out the trash" {
+		t.Fatalf("got Named(\"what\")=%q, want \"take out the trash\"", gotWhat)
+	}
+
+	want := ArgumentList{"remind", "5m", "take", "out", "the", "trash"}
+	if !reflect.DeepEqual(gotArgs, want) {
+		t.Fatalf("got args %v, want %v (the leading word must not be dropped as a literal invoke)", gotArgs, want)
+	}
+}
+
+func TestMessageHandlerLiteralInvokeBeatsPattern(t *testing.T) {
+	var literalCalled, patternCalled bool
+
+	literal := &stubCommand{
+		invokes: []string{"remind"},
+		execFn: func(ctx Context) error {
+			literalCalled = true
+			return nil
+		},
+	}
+	pattern := &regexStubCommand{
+		stubCommand: stubCommand{
+			execFn: func(ctx Context) error {
+				patternCalled = true
+				return nil
+			},
+		},
+		patterns: []*regexp.Regexp{regexp.MustCompile(`^remind .+$`)},
+	}
+
+	h := NewHandler(&Config{GeneralPrefix: "!", AllowDM: true}).(*handler)
+	h.RegisterCommand(literal)
+	h.RegisterCommand(pattern)
+
+	s := newTestSession("bot1")
+	msg := newTestDMMessage(t, s, "!remind 5m take out the trash")
+
+	h.messageHandler(s, msg, false)
+
+	if !literalCalled {
+		t.Fatal("expected the literal invoke command to run")
+	}
+	if patternCalled {
+		t.Fatal("expected the RegexCommand to be skipped when a literal invoke matches")
+	}
+}
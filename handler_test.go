@@ -0,0 +1,108 @@
+package shireikan
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// stubCommand is a minimal Command used across the handler tests
+// in this package. It always implements SubCommander so nested
+// dispatch can be exercised without a separate type per test.
+type stubCommand struct {
+	invokes []string
+	subs    []Command
+	execFn  func(ctx Context) error
+}
+
+func (c *stubCommand) GetInvokes() []string           { return c.invokes }
+func (c *stubCommand) GetDescription() string         { return "stub" }
+func (c *stubCommand) IsExecutableInDMChannels() bool { return true }
+
+func (c *stubCommand) Exec(ctx Context) error {
+	if c.execFn == nil {
+		return nil
+	}
+	return c.execFn(ctx)
+}
+
+func (c *stubCommand) GetSubCommands() []Command { return c.subs }
+
+// newTestSession returns a discordgo.Session backed by an empty,
+// offline State, with the given ID set as the bot's own user so
+// messageHandler's self-message check does not short-circuit.
+func newTestSession(botID string) *discordgo.Session {
+	s := &discordgo.Session{State: discordgo.NewState()}
+	s.State.User = &discordgo.User{ID: botID}
+	return s
+}
+
+// newTestDMMessage builds a message sent in a DM channel, adding
+// that channel to s.State so messageHandler resolves it without
+// falling back to a live API call.
+func newTestDMMessage(t *testing.T, s *discordgo.Session, content string) *discordgo.Message {
+	t.Helper()
+
+	channel := &discordgo.Channel{ID: "chan1", Type: discordgo.ChannelTypeDM}
+	if err := s.State.ChannelAdd(channel); err != nil {
+		t.Fatalf("failed to add test channel: %v", err)
+	}
+
+	return &discordgo.Message{
+		ID:        "msg1",
+		ChannelID: channel.ID,
+		Content:   content,
+		Author:    &discordgo.User{ID: "user1"},
+	}
+}
+
+func TestRegisterCommandSubCommandCollisionPanics(t *testing.T) {
+	h := NewHandler(&Config{}).(*handler)
+
+	parent := &stubCommand{
+		invokes: []string{"parent"},
+		subs: []Command{
+			&stubCommand{invokes: []string{"child"}},
+			&stubCommand{invokes: []string{"child"}},
+		},
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected RegisterCommand to panic on colliding sub command invokes")
+		}
+	}()
+
+	h.RegisterCommand(parent)
+}
+
+func TestMessageHandlerSubCommandDescent(t *testing.T) {
+	var gotPath []string
+	var gotArgs ArgumentList
+
+	leaf := &stubCommand{
+		invokes: []string{"set"},
+		execFn: func(ctx Context) error {
+			gotPath = ctx.InvokePath()
+			gotArgs = ctx.GetArgs()
+			return nil
+		},
+	}
+	mid := &stubCommand{invokes: []string{"config"}, subs: []Command{leaf}}
+
+	h := NewHandler(&Config{GeneralPrefix: "!", AllowDM: true}).(*handler)
+	h.RegisterCommand(mid)
+
+	s := newTestSession("bot1")
+	msg := newTestDMMessage(t, s, "!config set prefix")
+
+	h.messageHandler(s, msg, false)
+
+	if want := []string{"config", "set"}; !reflect.DeepEqual(gotPath, want) {
+		t.Fatalf("got invoke path %v, want %v", gotPath, want)
+	}
+	if want := (ArgumentList{"prefix"}); !reflect.DeepEqual(gotArgs, want) {
+		t.Fatalf("got args %v, want %v", gotArgs, want)
+	}
+}
@@ -0,0 +1,70 @@
+package shireikan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultHelpCommand is the default implementation of the
+// built-in help command which is registered automatically
+// when Config.UseDefaultHelpCommand is set to true.
+type defaultHelpCommand struct{}
+
+func (c *defaultHelpCommand) GetInvokes() []string {
+	return []string{"help"}
+}
+
+func (c *defaultHelpCommand) GetDescription() string {
+	return "Displays this help message."
+}
+
+func (c *defaultHelpCommand) IsExecutableInDMChannels() bool {
+	return true
+}
+
+func (c *defaultHelpCommand) Exec(ctx Context) error {
+	h, ok := ctx.GetObject(ObjectMapKeyHandler).(Handler)
+	if !ok {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("**Available commands:**\n")
+	for _, cmd := range h.GetCommandInstances() {
+		writeCommandHelp(&sb, cmd, "")
+	}
+
+	_, err := ctx.GetSession().ChannelMessageSend(ctx.GetChannel().ID, sb.String())
+	return err
+}
+
+// writeCommandHelp writes a single line for cmd and then
+// recurses into its sub commands, if any, indenting each
+// nested level so the rendered tree mirrors the invoke path
+// needed to reach a given command.
+func writeCommandHelp(sb *strings.Builder, cmd Command, indent string) {
+	invokes := cmd.GetInvokes()
+	if len(invokes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "%s- `%s", indent, invokes[0])
+	if doc, ok := cmd.(Documented); ok {
+		if usage := doc.GetUsage(); usage != "" {
+			fmt.Fprintf(sb, " %s", usage)
+		}
+	}
+	fmt.Fprintf(sb, "` - %s", cmd.GetDescription())
+	if doc, ok := cmd.(Documented); ok {
+		if minArgs := doc.GetMinArgs(); minArgs > 0 {
+			fmt.Fprintf(sb, " (min. %d argument(s))", minArgs)
+		}
+	}
+	sb.WriteString("\n")
+
+	if sc, ok := cmd.(SubCommander); ok {
+		for _, child := range sc.GetSubCommands() {
+			writeCommandHelp(sb, child, indent+"  ")
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package shireikan
+
+// MiddlewareLayer specifies at which point in the command
+// execution pipeline a Middleware is invoked. Layers are bit
+// flags so a Middleware can subscribe to multiple layers.
+type MiddlewareLayer int
+
+const (
+	// LayerBeforeCommand is executed before a commands Exec
+	// handler is called.
+	LayerBeforeCommand MiddlewareLayer = 1 << iota
+	// LayerAfterCommand is executed after a commands Exec
+	// handler returned successfully.
+	LayerAfterCommand
+)
+
+// Middleware specifies a handler which is executed on the
+// layers returned by GetLayer, before and/or after a command
+// is executed.
+type Middleware interface {
+
+	// GetLayer returns the layers this middleware should be
+	// executed on.
+	GetLayer() MiddlewareLayer
+
+	// Handle is called with the command about to be (or
+	// having been) executed, the current Context and the
+	// layer this invocation belongs to. If next is false or
+	// err is non-nil, further processing of the command is
+	// stopped.
+	Handle(cmd Command, ctx Context, layer MiddlewareLayer) (next bool, err error)
+}
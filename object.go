@@ -0,0 +1,8 @@
+package shireikan
+
+const (
+	// ObjectMapKeyHandler is the key the currently active
+	// Handler instance is stored with on a Context's
+	// object map.
+	ObjectMapKeyHandler = "handler"
+)
@@ -0,0 +1,94 @@
+// Package parser provides a shellwords-style ArgsParser
+// implementation for shireikan which supports single and
+// double quoting as well as backslash escapes.
+package parser
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnterminatedQuote is returned by Parse when the content
+// contains a quote which was never closed.
+var ErrUnterminatedQuote = errors.New("unterminated quote")
+
+// Parse splits content into a slice of arguments, shell-style.
+//
+// Arguments may be wrapped in single or double quotes to
+// include whitespace. Inside double quotes, \" and \\ are
+// unescaped to a literal " and \ respectively. Inside single
+// quotes, all characters, including backslashes, are taken
+// literally. An unterminated single or double quote results
+// in ErrUnterminatedQuote.
+func Parse(content string) ([]string, error) {
+	var (
+		args   []string
+		cur    strings.Builder
+		hasCur bool
+	)
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if hasCur {
+				args = append(args, cur.String())
+				cur.Reset()
+				hasCur = false
+			}
+			i++
+
+		case r == '\'':
+			hasCur = true
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					closed = true
+					i++
+					break
+				}
+				cur.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, ErrUnterminatedQuote
+			}
+
+		case r == '"':
+			hasCur = true
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				if runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+					cur.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				cur.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, ErrUnterminatedQuote
+			}
+
+		default:
+			hasCur = true
+			cur.WriteRune(r)
+			i++
+		}
+	}
+
+	if hasCur {
+		args = append(args, cur.String())
+	}
+
+	return args, nil
+}
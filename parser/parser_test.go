@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "plain words",
+			in:   "say hi there",
+			want: []string{"say", "hi", "there"},
+		},
+		{
+			name: "double quoted with escaped quote",
+			in:   `say "he said \"hi\""`,
+			want: []string{"say", `he said "hi"`},
+		},
+		{
+			name: "single quotes preserve backslash",
+			in:   `say 'he said \"hi\"'`,
+			want: []string{"say", `he said \"hi\"`},
+		},
+		{
+			name: "empty quoted string",
+			in:   `say ""`,
+			want: []string{"say", ""},
+		},
+		{
+			name: "adjacent quotes",
+			in:   `"foo""bar"`,
+			want: []string{"foobar"},
+		},
+		{
+			name: "escaped backslash in double quotes",
+			in:   `"a\\b"`,
+			want: []string{`a\b`},
+		},
+		{
+			name:    "unterminated double quote",
+			in:      `say "hi`,
+			wantErr: true,
+		},
+		{
+			name:    "unterminated single quote",
+			in:      `say 'hi`,
+			wantErr: true,
+		},
+		{
+			name:    "trailing backslash in double quotes",
+			in:      `"hi\`,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Parse(c.in)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %#v, want %#v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package shireikan
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Prefixer determines the prefix used to invoke commands for a
+// given message. ok reports whether prefix actually applies to
+// m; when false, the message is not treated as a command. err
+// is non-nil when resolving the prefix failed unexpectedly
+// (e.g. a guild prefix lookup), and is surfaced through
+// Config.OnError as ErrTypGuildPrefixGetter.
+type Prefixer func(s *discordgo.Session, m *discordgo.Message) (prefix string, ok bool, err error)
+
+// StaticPrefix returns a Prefixer matching any of the given,
+// fixed prefixes against the message content. The first prefix
+// to match wins.
+func StaticPrefix(prefixes ...string) Prefixer {
+	return func(_ *discordgo.Session, m *discordgo.Message) (string, bool, error) {
+		for _, prefix := range prefixes {
+			if prefix != "" && strings.HasPrefix(m.Content, prefix) {
+				return prefix, true, nil
+			}
+		}
+		return "", false, nil
+	}
+}
+
+// GuildPrefix returns a Prefixer which looks up a guild's
+// individual prefix via getter and matches it against the
+// message content. It returns ok = false for messages sent
+// outside of a guild or when getter returns an empty prefix.
+// An error returned by getter is propagated so it still
+// reaches Config.OnError as ErrTypGuildPrefixGetter.
+func GuildPrefix(getter func(guildID string) (string, error)) Prefixer {
+	return func(_ *discordgo.Session, m *discordgo.Message) (string, bool, error) {
+		if m.GuildID == "" {
+			return "", false, nil
+		}
+
+		prefix, err := getter(m.GuildID)
+		if err != nil {
+			return "", false, err
+		}
+
+		if prefix == "" || !strings.HasPrefix(m.Content, prefix) {
+			return "", false, nil
+		}
+
+		return prefix, true, nil
+	}
+}
+
+// MentionPrefix returns a Prefixer matching the bot's own
+// mention (either "<@ID>" or "<@!ID>") as a prefix, with an
+// optional single trailing space also consumed as part of the
+// prefix.
+func MentionPrefix() Prefixer {
+	return func(s *discordgo.Session, m *discordgo.Message) (string, bool, error) {
+		if s.State.User == nil {
+			return "", false, nil
+		}
+
+		for _, mention := range []string{
+			"<@" + s.State.User.ID + ">",
+			"<@!" + s.State.User.ID + ">",
+		} {
+			if !strings.HasPrefix(m.Content, mention) {
+				continue
+			}
+
+			if strings.HasPrefix(m.Content[len(mention):], " ") {
+				return mention + " ", true, nil
+			}
+			return mention, true, nil
+		}
+
+		return "", false, nil
+	}
+}
+
+// AnyPrefix returns a Prefixer which tries each of ps in order
+// and returns the first one that matches. It returns the first
+// error reported by any of ps, stopping the chain there, same
+// as a single Prefixer would.
+func AnyPrefix(ps ...Prefixer) Prefixer {
+	return func(s *discordgo.Session, m *discordgo.Message) (string, bool, error) {
+		for _, p := range ps {
+			prefix, ok, err := p(s, m)
+			if err != nil {
+				return "", false, err
+			}
+			if ok {
+				return prefix, true, nil
+			}
+		}
+		return "", false, nil
+	}
+}
@@ -0,0 +1,84 @@
+package shireikan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestStaticPrefix(t *testing.T) {
+	p := StaticPrefix("!", "?")
+
+	if prefix, ok, err := p(nil, &discordgo.Message{Content: "!ping"}); !ok || err != nil || prefix != "!" {
+		t.Fatalf("got prefix=%q ok=%v err=%v, want prefix=\"!\" ok=true err=nil", prefix, ok, err)
+	}
+	if prefix, ok, err := p(nil, &discordgo.Message{Content: "?ping"}); !ok || err != nil || prefix != "?" {
+		t.Fatalf("got prefix=%q ok=%v err=%v, want prefix=\"?\" ok=true err=nil", prefix, ok, err)
+	}
+	if _, ok, err := p(nil, &discordgo.Message{Content: "ping"}); ok || err != nil {
+		t.Fatalf("got ok=%v err=%v, want ok=false err=nil for unprefixed content", ok, err)
+	}
+}
+
+func TestGuildPrefix(t *testing.T) {
+	getter := func(guildID string) (string, error) {
+		switch guildID {
+		case "guild1":
+			return "!", nil
+		case "broken":
+			return "", errors.New("lookup failed")
+		default:
+			return "", nil
+		}
+	}
+	p := GuildPrefix(getter)
+
+	if prefix, ok, err := p(nil, &discordgo.Message{GuildID: "guild1", Content: "!ping"}); !ok || err != nil || prefix != "!" {
+		t.Fatalf("got prefix=%q ok=%v err=%v, want prefix=\"!\" ok=true err=nil", prefix, ok, err)
+	}
+	if _, ok, err := p(nil, &discordgo.Message{Content: "!ping"}); ok || err != nil {
+		t.Fatalf("got ok=%v err=%v, want ok=false err=nil outside a guild", ok, err)
+	}
+	if _, ok, err := p(nil, &discordgo.Message{GuildID: "other", Content: "!ping"}); ok || err != nil {
+		t.Fatalf("got ok=%v err=%v, want ok=false err=nil when the guild has no prefix", ok, err)
+	}
+	if _, ok, err := p(nil, &discordgo.Message{GuildID: "broken", Content: "!ping"}); ok || err == nil {
+		t.Fatalf("got ok=%v err=%v, want ok=false with the getter's error propagated", ok, err)
+	}
+}
+
+func TestMentionPrefix(t *testing.T) {
+	s := &discordgo.Session{State: discordgo.NewState()}
+	s.State.User = &discordgo.User{ID: "bot1"}
+	p := MentionPrefix()
+
+	if prefix, ok, err := p(s, &discordgo.Message{Content: "<@bot1> ping"}); !ok || err != nil || prefix != "<@bot1> " {
+		t.Fatalf("got prefix=%q ok=%v err=%v, want prefix=\"<@bot1> \" ok=true err=nil", prefix, ok, err)
+	}
+	if prefix, ok, err := p(s, &discordgo.Message{Content: "<@!bot1>ping"}); !ok || err != nil || prefix != "<@!bot1>" {
+		t.Fatalf("got prefix=%q ok=%v err=%v, want prefix=\"<@!bot1>\" ok=true err=nil", prefix, ok, err)
+	}
+	if _, ok, err := p(s, &discordgo.Message{Content: "<@other> ping"}); ok || err != nil {
+		t.Fatalf("got ok=%v err=%v, want ok=false err=nil for a different mention", ok, err)
+	}
+}
+
+func TestAnyPrefix(t *testing.T) {
+	erroringPrefixer := func(_ *discordgo.Session, _ *discordgo.Message) (string, bool, error) {
+		return "", false, errors.New("boom")
+	}
+	p := AnyPrefix(StaticPrefix("!"), erroringPrefixer, StaticPrefix("?"))
+
+	if prefix, ok, err := p(nil, &discordgo.Message{Content: "!ping"}); !ok || err != nil || prefix != "!" {
+		t.Fatalf("got prefix=%q ok=%v err=%v, want prefix=\"!\" ok=true err=nil", prefix, ok, err)
+	}
+
+	if _, ok, err := p(nil, &discordgo.Message{Content: "?ping"}); ok || err == nil {
+		t.Fatalf("got ok=%v err=%v, want the erroring Prefixer before it to stop the chain", ok, err)
+	}
+
+	if _, ok, err := AnyPrefix(StaticPrefix("!"))(nil, &discordgo.Message{Content: "ping"}); ok || err != nil {
+		t.Fatalf("got ok=%v err=%v, want ok=false err=nil when nothing matches", ok, err)
+	}
+}